@@ -0,0 +1,86 @@
+package tefmocheck
+
+import (
+	"encoding/json"
+	"os"
+)
+
+// checkResultSchemaVersion is incremented whenever CheckResult's fields change
+// in a way that isn't backwards compatible for consumers.
+const checkResultSchemaVersion = 1
+
+// Severity indicates how urgently a fired check should be triaged.
+type Severity string
+
+const (
+	SeverityInfo    Severity = "info"
+	SeverityWarning Severity = "warning"
+	SeverityError   Severity = "error"
+)
+
+// LogOffset points at the byte range of a log that is evidence for a fired
+// check, so a dashboard can link directly to the relevant excerpt.
+type LogOffset struct {
+	Output string `json:"output"`
+	Start  int64  `json:"start"`
+	End    int64  `json:"end"`
+}
+
+// CheckResult is the structured, machine-readable counterpart to a fired
+// check's Name()/DebugText() synthetic test, for consumption by dashboards and
+// ResultDB-like sinks.
+type CheckResult struct {
+	SchemaVersion   int               `json:"schema_version"`
+	Name            string            `json:"name"`
+	Category        string            `json:"category"`
+	SwarmingTaskId  string            `json:"swarming_task_id"`
+	SwarmingBotId   string            `json:"swarming_bot_id"`
+	State           string            `json:"state"`
+	Failure         bool              `json:"failure"`
+	InternalFailure bool              `json:"internal_failure"`
+	Tags            map[string]string `json:"tags,omitempty"`
+	Dimensions      map[string]string `json:"dimensions,omitempty"`
+	LogOffsets      []LogOffset       `json:"log_offsets,omitempty"`
+	Severity        Severity          `json:"severity"`
+}
+
+// StructuredResultCheck is implemented by FailureModeChecks that can describe
+// a fired result with structured fields, in addition to the Name()/DebugText()
+// synthetic test every FailureModeCheck already provides.
+type StructuredResultCheck interface {
+	FailureModeCheck
+	// Result returns the structured result of the most recent Check call,
+	// whether or not that call fired.
+	Result() CheckResult
+}
+
+// newCheckResult fills in the fields common to every CheckResult: its schema
+// version and the check's name.
+func newCheckResult(name string) CheckResult {
+	return CheckResult{
+		SchemaVersion: checkResultSchemaVersion,
+		Name:          name,
+	}
+}
+
+// CollectCheckResults gathers the structured results of whichever fired checks
+// implement StructuredResultCheck.
+func CollectCheckResults(fired []FailureModeCheck) []CheckResult {
+	var results []CheckResult
+	for _, check := range fired {
+		if structured, ok := check.(StructuredResultCheck); ok {
+			results = append(results, structured.Result())
+		}
+	}
+	return results
+}
+
+// WriteCheckResultsJSON serializes results to path as JSON, for consumption by
+// dashboards and ResultDB-like sinks alongside the synthetic-test summary.
+func WriteCheckResultsJSON(path string, results []CheckResult) error {
+	b, err := json.MarshalIndent(results, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, b, 0644)
+}
@@ -0,0 +1,147 @@
+package tefmocheck
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func resultCheckOutputs(state string, failure, internalFailure bool, taskId, botId string) *TestingOutputs {
+	return &TestingOutputs{
+		SwarmingSummary: &SwarmingTaskSummary{
+			Results: &SwarmingRpcsTaskResult{
+				State:           state,
+				Failure:         failure,
+				InternalFailure: internalFailure,
+				TaskId:          taskId,
+				BotId:           botId,
+			},
+		},
+	}
+}
+
+func TestTaskFailureCheck_Result(t *testing.T) {
+	check := &taskFailureCheck{}
+	to := resultCheckOutputs("COMPLETED", true, false, "task123", "bot456")
+	if !check.Check(to) {
+		t.Fatal("Check() = false, want true")
+	}
+	r := check.Result()
+	if r.SchemaVersion != checkResultSchemaVersion {
+		t.Errorf("got SchemaVersion %d, want %d", r.SchemaVersion, checkResultSchemaVersion)
+	}
+	if r.Name != "task_failure" {
+		t.Errorf("got Name %q, want %q", r.Name, "task_failure")
+	}
+	if r.State != "COMPLETED" {
+		t.Errorf("got State %q, want %q", r.State, "COMPLETED")
+	}
+	if !r.Failure {
+		t.Error("got Failure false, want true")
+	}
+	if r.InternalFailure {
+		t.Error("got InternalFailure true, want false")
+	}
+	if r.SwarmingTaskId != "task123" {
+		t.Errorf("got SwarmingTaskId %q, want %q", r.SwarmingTaskId, "task123")
+	}
+	if r.SwarmingBotId != "bot456" {
+		t.Errorf("got SwarmingBotId %q, want %q", r.SwarmingBotId, "bot456")
+	}
+	if r.Severity != SeverityError {
+		t.Errorf("got Severity %q, want %q", r.Severity, SeverityError)
+	}
+}
+
+func TestTaskInternalFailureCheck_Result(t *testing.T) {
+	check := &taskInternalFailureCheck{}
+	to := resultCheckOutputs("COMPLETED", false, true, "task123", "bot456")
+	if !check.Check(to) {
+		t.Fatal("Check() = false, want true")
+	}
+	r := check.Result()
+	if !r.InternalFailure {
+		t.Error("got InternalFailure false, want true")
+	}
+	if r.Failure {
+		t.Error("got Failure true, want false")
+	}
+	if r.Severity != SeverityError {
+		t.Errorf("got Severity %q, want %q", r.Severity, SeverityError)
+	}
+}
+
+func TestTaskStateCheck_Result(t *testing.T) {
+	check := &taskStateCheck{State: "TIMED_OUT"}
+	to := resultCheckOutputs("TIMED_OUT", false, false, "task123", "bot456")
+	if !check.Check(to) {
+		t.Fatal("Check() = false, want true")
+	}
+	r := check.Result()
+	if r.State != "TIMED_OUT" {
+		t.Errorf("got State %q, want %q", r.State, "TIMED_OUT")
+	}
+	if r.Severity != SeverityWarning {
+		t.Errorf("got Severity %q, want %q", r.Severity, SeverityWarning)
+	}
+}
+
+func TestCollectCheckResults(t *testing.T) {
+	to := resultCheckOutputs("COMPLETED", true, false, "task123", "bot456")
+	failureCheck := &taskFailureCheck{}
+	if !failureCheck.Check(to) {
+		t.Fatal("Check() = false, want true")
+	}
+
+	results := CollectCheckResults([]FailureModeCheck{failureCheck, &fixedCheck{name: "unstructured", fired: true}})
+	if len(results) != 1 {
+		t.Fatalf("got %d results, want 1 (only the fired check that implements StructuredResultCheck): %+v", len(results), results)
+	}
+	if results[0].Name != "task_failure" {
+		t.Errorf("got Name %q, want %q", results[0].Name, "task_failure")
+	}
+}
+
+func TestWriteCheckResultsJSON(t *testing.T) {
+	results := []CheckResult{
+		{
+			SchemaVersion:  checkResultSchemaVersion,
+			Name:           "task_failure",
+			Category:       "task_failure",
+			State:          "COMPLETED",
+			Failure:        true,
+			SwarmingTaskId: "task123",
+			SwarmingBotId:  "bot456",
+			Severity:       SeverityError,
+		},
+	}
+
+	path := filepath.Join(t.TempDir(), "results.json")
+	if err := WriteCheckResultsJSON(path, results); err != nil {
+		t.Fatalf("WriteCheckResultsJSON: %s", err)
+	}
+
+	b, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile: %s", err)
+	}
+	var roundTripped []CheckResult
+	if err := json.Unmarshal(b, &roundTripped); err != nil {
+		t.Fatalf("Unmarshal: %s", err)
+	}
+	if len(roundTripped) != 1 || !reflect.DeepEqual(roundTripped[0], results[0]) {
+		t.Errorf("got %+v after round-tripping through JSON, want %+v", roundTripped, results)
+	}
+
+	var raw []map[string]interface{}
+	if err := json.Unmarshal(b, &raw); err != nil {
+		t.Fatalf("Unmarshal into raw map: %s", err)
+	}
+	for _, field := range []string{"schema_version", "name", "category", "swarming_task_id", "swarming_bot_id", "state", "failure", "severity"} {
+		if _, ok := raw[0][field]; !ok {
+			t.Errorf("JSON output missing field %q: %s", field, b)
+		}
+	}
+}
@@ -0,0 +1,116 @@
+package tefmocheck
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"sync"
+	"time"
+)
+
+// RunOptions configures RunChecks.
+type RunOptions struct {
+	// Workers bounds how many checks run concurrently. Zero means GOMAXPROCS.
+	Workers int
+	// PerCheckTimeout bounds how long a single check is allowed to run before
+	// it is treated as failed. Zero means no timeout.
+	PerCheckTimeout time.Duration
+}
+
+// Result is the outcome of a FailureModeCheck that fired.
+type Result struct {
+	Name      string
+	DebugText string
+}
+
+// RunChecks runs tiers of checks against to using a bounded worker pool. Checks
+// within a tier run concurrently; as soon as any check in a tier fires, lower
+// tiers are skipped, preserving the existing most-specific-check-only
+// semantics (e.g. TaskStateChecks before TaskMetadataChecks). A check that
+// panics or exceeds opts.PerCheckTimeout is recorded as a synthetic
+// "tefmocheck_internal_error/<name>" result rather than crashing the run.
+func RunChecks(ctx context.Context, tiers [][]FailureModeCheck, to *TestingOutputs, opts RunOptions) []Result {
+	workers := opts.Workers
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	for _, tier := range tiers {
+		if results := runCheckTier(ctx, tier, to, workers, opts.PerCheckTimeout); len(results) > 0 {
+			return results
+		}
+	}
+	return nil
+}
+
+// runCheckTier runs checks concurrently, bounded by workers, and returns the
+// results of those that fired.
+func runCheckTier(ctx context.Context, checks []FailureModeCheck, to *TestingOutputs, workers int, perCheckTimeout time.Duration) []Result {
+	sem := make(chan struct{}, workers)
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	var results []Result
+
+	for _, check := range checks {
+		check := check
+		wg.Add(1)
+		sem <- struct{}{}
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+			if result, fired := runCheck(ctx, check, to, perCheckTimeout); fired {
+				mu.Lock()
+				results = append(results, result)
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	return results
+}
+
+// runCheck runs a single check, enforcing perCheckTimeout (if positive) and
+// recovering from panics. Both a timeout and a panic are surfaced as a fired
+// synthetic "tefmocheck_internal_error" result so infra failures are visible
+// instead of silently dropped.
+func runCheck(ctx context.Context, check FailureModeCheck, to *TestingOutputs, perCheckTimeout time.Duration) (Result, bool) {
+	checkCtx := ctx
+	if perCheckTimeout > 0 {
+		var cancel context.CancelFunc
+		checkCtx, cancel = context.WithTimeout(ctx, perCheckTimeout)
+		defer cancel()
+	}
+
+	type outcome struct {
+		fired    bool
+		panicVal interface{}
+	}
+	done := make(chan outcome, 1)
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				done <- outcome{panicVal: r}
+			}
+		}()
+		done <- outcome{fired: check.Check(to)}
+	}()
+
+	select {
+	case o := <-done:
+		if o.panicVal != nil {
+			return internalErrorResult(check, fmt.Sprintf("panicked: %v", o.panicVal)), true
+		}
+		if !o.fired {
+			return Result{}, false
+		}
+		return Result{Name: check.Name(), DebugText: check.DebugText()}, true
+	case <-checkCtx.Done():
+		return internalErrorResult(check, fmt.Sprintf("did not complete within %s: %s", perCheckTimeout, checkCtx.Err())), true
+	}
+}
+
+func internalErrorResult(check FailureModeCheck, reason string) Result {
+	return Result{
+		Name:      fmt.Sprintf("tefmocheck_internal_error/%s", check.Name()),
+		DebugText: fmt.Sprintf("Check %q %s.", check.Name(), reason),
+	}
+}
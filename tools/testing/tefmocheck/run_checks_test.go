@@ -0,0 +1,128 @@
+package tefmocheck
+
+import (
+	"context"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+)
+
+// fixedCheck is a FailureModeCheck whose Check result is fixed at construction,
+// for exercising RunChecks' control flow without real Swarming data.
+type fixedCheck struct {
+	name  string
+	fired bool
+}
+
+func (c *fixedCheck) Check(to *TestingOutputs) bool { return c.fired }
+func (c *fixedCheck) Name() string                  { return c.name }
+func (c *fixedCheck) DebugText() string             { return "" }
+
+// panicCheck is a FailureModeCheck that always panics, for exercising
+// RunChecks' panic recovery.
+type panicCheck struct{}
+
+func (c *panicCheck) Check(to *TestingOutputs) bool { panic("boom") }
+func (c *panicCheck) Name() string                  { return "panics" }
+func (c *panicCheck) DebugText() string             { return "" }
+
+// sleepCheck is a FailureModeCheck that blocks for dur, for exercising
+// RunChecks' worker pool and per-check timeout.
+type sleepCheck struct {
+	name string
+	dur  time.Duration
+}
+
+func (c *sleepCheck) Check(to *TestingOutputs) bool {
+	time.Sleep(c.dur)
+	return false
+}
+
+func (c *sleepCheck) Name() string      { return c.name }
+func (c *sleepCheck) DebugText() string { return "" }
+
+func TestRunChecks_PanicRecovered(t *testing.T) {
+	results := RunChecks(context.Background(), [][]FailureModeCheck{{&panicCheck{}}}, &TestingOutputs{}, RunOptions{})
+	if len(results) != 1 {
+		t.Fatalf("got %d results, want 1: %+v", len(results), results)
+	}
+	if want := "tefmocheck_internal_error/panics"; results[0].Name != want {
+		t.Errorf("got name %q, want %q", results[0].Name, want)
+	}
+	if !strings.Contains(results[0].DebugText, "panicked") {
+		t.Errorf("DebugText %q doesn't mention the panic", results[0].DebugText)
+	}
+}
+
+func TestRunChecks_TimeoutReported(t *testing.T) {
+	opts := RunOptions{PerCheckTimeout: time.Millisecond}
+	checks := [][]FailureModeCheck{{&sleepCheck{name: "slow", dur: time.Second}}}
+	start := time.Now()
+	results := RunChecks(context.Background(), checks, &TestingOutputs{}, opts)
+	if elapsed := time.Since(start); elapsed >= time.Second {
+		t.Errorf("RunChecks took %s, want it to return well before the check's %s sleep", elapsed, time.Second)
+	}
+	if len(results) != 1 {
+		t.Fatalf("got %d results, want 1: %+v", len(results), results)
+	}
+	if want := "tefmocheck_internal_error/slow"; results[0].Name != want {
+		t.Errorf("got name %q, want %q", results[0].Name, want)
+	}
+}
+
+func TestRunChecks_TierShortCircuit(t *testing.T) {
+	tiers := [][]FailureModeCheck{
+		{&fixedCheck{name: "specific", fired: true}},
+		{&fixedCheck{name: "generic", fired: true}},
+	}
+	results := RunChecks(context.Background(), tiers, &TestingOutputs{}, RunOptions{})
+	if len(results) != 1 {
+		t.Fatalf("got %d results, want 1: %+v", len(results), results)
+	}
+	if results[0].Name != "specific" {
+		t.Errorf("got %q, want the first tier's check to win, not a lower tier's", results[0].Name)
+	}
+}
+
+func TestRunChecks_FallsThroughToLowerTier(t *testing.T) {
+	tiers := [][]FailureModeCheck{
+		{&fixedCheck{name: "specific", fired: false}},
+		{&fixedCheck{name: "generic", fired: true}},
+	}
+	results := RunChecks(context.Background(), tiers, &TestingOutputs{}, RunOptions{})
+	if len(results) != 1 || results[0].Name != "generic" {
+		t.Fatalf("got %+v, want only the lower tier's check to fire", results)
+	}
+}
+
+func benchmarkChecks(n int) []FailureModeCheck {
+	checks := make([]FailureModeCheck, n)
+	for i := range checks {
+		checks[i] = &sleepCheck{name: "sleep", dur: time.Millisecond}
+	}
+	return checks
+}
+
+// BenchmarkRunChecksSerial simulates the old behavior of running checks one at
+// a time, as a baseline to compare against BenchmarkRunChecksParallel.
+func BenchmarkRunChecksSerial(b *testing.B) {
+	checks := benchmarkChecks(32)
+	to := &TestingOutputs{}
+	for i := 0; i < b.N; i++ {
+		for _, check := range checks {
+			check.Check(to)
+		}
+	}
+}
+
+// BenchmarkRunChecksParallel exercises RunChecks' worker pool over the same
+// checks used by BenchmarkRunChecksSerial.
+func BenchmarkRunChecksParallel(b *testing.B) {
+	checks := benchmarkChecks(32)
+	to := &TestingOutputs{}
+	opts := RunOptions{Workers: runtime.GOMAXPROCS(0)}
+	for i := 0; i < b.N; i++ {
+		RunChecks(context.Background(), [][]FailureModeCheck{checks}, to, opts)
+	}
+}
@@ -0,0 +1,101 @@
+package tefmocheck
+
+import (
+	"fmt"
+	"path"
+	"time"
+)
+
+// taskDurationCheck fires when a COMPLETED task's wall-clock runtime falls in
+// [thresholdRatio, upperBoundRatio) of its configured execution or I/O
+// timeout, surfacing tasks that are trending toward TIMED_OUT before they
+// start flaking as such. upperBoundRatio of 0 means no upper bound, so that
+// TaskDurationChecks' instances are mutually exclusive: at most one matches a
+// given task, and none matches a task below the lowest threshold.
+type taskDurationCheck struct {
+	thresholdRatio  float64
+	upperBoundRatio float64
+	swarmingSummary *SwarmingTaskSummary
+	duration        time.Duration
+	timeout         time.Duration
+	ratio           float64
+}
+
+// NewTaskDurationCheck returns a FailureModeCheck that fires when a COMPLETED
+// task's runtime falls in [thresholdRatio, upperBoundRatio) of its configured
+// timeout. Pass upperBoundRatio of 0 for no upper bound.
+func NewTaskDurationCheck(thresholdRatio, upperBoundRatio float64) FailureModeCheck {
+	return &taskDurationCheck{thresholdRatio: thresholdRatio, upperBoundRatio: upperBoundRatio}
+}
+
+func (c *taskDurationCheck) Check(to *TestingOutputs) bool {
+	c.swarmingSummary = to.SwarmingSummary
+	results := c.swarmingSummary.Results
+	if results.State != "COMPLETED" {
+		return false
+	}
+	started, err := time.Parse(time.RFC3339Nano, results.StartedTs)
+	if err != nil {
+		return false
+	}
+	completed, err := time.Parse(time.RFC3339Nano, results.CompletedTs)
+	if err != nil {
+		return false
+	}
+	c.duration = completed.Sub(started)
+
+	// Only the task slice that was actually scheduled (Results.CurrentTaskSlice)
+	// has a timeout the task could actually hit; fallback slices that were never
+	// picked up by a bot are irrelevant, even if their timeouts are tighter.
+	slices := c.swarmingSummary.Request.TaskSlices
+	sliceIdx := int(c.swarmingSummary.Results.CurrentTaskSlice)
+	if sliceIdx < 0 || sliceIdx >= len(slices) {
+		return false
+	}
+	properties := slices[sliceIdx].Properties
+
+	// Use the smaller of the execution and I/O timeouts, i.e. whichever bound
+	// the task would actually hit first, not the most lenient of the two.
+	timeoutSecs := minNonZero(properties.ExecutionTimeoutSecs, properties.IoTimeoutSecs)
+	if timeoutSecs == 0 {
+		return false
+	}
+	c.timeout = time.Duration(timeoutSecs) * time.Second
+	c.ratio = c.duration.Seconds() / c.timeout.Seconds()
+	if c.ratio < c.thresholdRatio {
+		return false
+	}
+	return c.upperBoundRatio == 0 || c.ratio < c.upperBoundRatio
+}
+
+// minNonZero returns the smaller of a and b, ignoring whichever is zero (i.e.
+// not configured). It returns 0 if both are zero.
+func minNonZero(a, b int64) int64 {
+	if a == 0 {
+		return b
+	}
+	if b == 0 {
+		return a
+	}
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func (c *taskDurationCheck) Name() string {
+	return path.Join("task_duration", fmt.Sprintf("%.0f_percent_of_timeout", c.thresholdRatio*100))
+}
+
+func (c *taskDurationCheck) DebugText() string {
+	return fmt.Sprintf("%s\nTask ran for %s, %.1f%% of its %s configured timeout.",
+		debugTextForSwarmingSummary(c.swarmingSummary), c.duration, c.ratio*100, c.timeout)
+}
+
+// TaskDurationChecks contains checks that fire as a task's runtime approaches
+// its configured timeout, before it starts flaking as a hard TIMED_OUT. The
+// ranges are mutually exclusive, so at most one fires for a given task.
+var TaskDurationChecks []FailureModeCheck = []FailureModeCheck{
+	NewTaskDurationCheck(0.9, 0.95),
+	NewTaskDurationCheck(0.95, 0),
+}
@@ -0,0 +1,49 @@
+package tefmocheck
+
+import "testing"
+
+func TestMinNonZero(t *testing.T) {
+	cases := []struct {
+		a, b, want int64
+	}{
+		{0, 0, 0},
+		{0, 5, 5},
+		{5, 0, 5},
+		{10, 20, 10},
+		{20, 10, 10},
+	}
+	for _, c := range cases {
+		if got := minNonZero(c.a, c.b); got != c.want {
+			t.Errorf("minNonZero(%d, %d) = %d, want %d", c.a, c.b, got, c.want)
+		}
+	}
+}
+
+func TestTaskDurationChecks_MutuallyExclusive(t *testing.T) {
+	// A task that blows well past the 95% threshold must fire exactly one of
+	// TaskDurationChecks, not both the 90% and 95% checks at once.
+	to := &TestingOutputs{
+		SwarmingSummary: &SwarmingTaskSummary{
+			Request: &SwarmingRpcsTaskRequest{
+				TaskSlices: []*SwarmingRpcsTaskSlice{
+					{Properties: &SwarmingRpcsTaskProperties{ExecutionTimeoutSecs: 100}},
+				},
+			},
+			Results: &SwarmingRpcsTaskResult{
+				State:       "COMPLETED",
+				StartedTs:   "2020-01-01T00:00:00Z",
+				CompletedTs: "2020-01-01T00:01:39Z", // 99s, 99% of the 100s timeout.
+			},
+		},
+	}
+
+	fired := 0
+	for _, check := range TaskDurationChecks {
+		if check.Check(to) {
+			fired++
+		}
+	}
+	if fired != 1 {
+		t.Errorf("got %d of TaskDurationChecks firing, want exactly 1", fired)
+	}
+}
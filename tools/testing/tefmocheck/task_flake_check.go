@@ -0,0 +1,144 @@
+package tefmocheck
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Swarming tags used by Skia's task scheduler to relate sibling attempts of the
+// same logical task to one another.
+const (
+	skAttemptTag   = "sk_attempt"
+	skNameTag      = "sk_name"
+	skRevisionTag  = "sk_revision"
+	builderNameTag = "builder_name"
+)
+
+// MultiTaskFailureModeCheck is like FailureModeCheck, but considers a group of
+// sibling Swarming task attempts together instead of a single task in isolation.
+type MultiTaskFailureModeCheck interface {
+	// Check returns true if the check fires for the given group of sibling
+	// attempts of the same logical task, ordered from earliest to latest attempt.
+	Check(attempts []*TestingOutputs) bool
+	// Name returns the name of the check, for use as a synthetic test name.
+	Name() string
+	// DebugText returns debugging information to include in the output of the
+	// synthetic test.
+	DebugText() string
+}
+
+// taskFlakeCheck fires when an earlier attempt of a logical task failed but a
+// later attempt of the same logical task succeeded.
+type taskFlakeCheck struct {
+	attempts []*TestingOutputs
+}
+
+func (c *taskFlakeCheck) Check(attempts []*TestingOutputs) bool {
+	c.attempts = attempts
+	sawFailure := false
+	for _, a := range attempts {
+		results := a.SwarmingSummary.Results
+		// A non-COMPLETED state (e.g. TIMED_OUT, BOT_DIED, KILLED) is a prior
+		// failure just as much as a COMPLETED task with Failure/InternalFailure
+		// set; only a clean COMPLETED run should end the streak.
+		if results.State != "COMPLETED" || results.Failure || results.InternalFailure {
+			sawFailure = true
+			continue
+		}
+		if sawFailure {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *taskFlakeCheck) Name() string {
+	return "flake_on_retry"
+}
+
+func (c *taskFlakeCheck) DebugText() string {
+	lines := make([]string, 0, len(c.attempts)+1)
+	lines = append(lines, "Sibling Swarming attempts of the same logical task:")
+	for _, a := range c.attempts {
+		r := a.SwarmingSummary.Results
+		lines = append(lines, fmt.Sprintf("  task %s: state %s, ran on bot %s", r.TaskId, r.State, r.BotId))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// MultiTaskChecks contains checks that consider all sibling attempts of a
+// logical task together.
+var MultiTaskChecks []MultiTaskFailureModeCheck = []MultiTaskFailureModeCheck{
+	&taskFlakeCheck{},
+}
+
+// siblingAttemptKey groups TestingOutputs by the (builder_name, sk_name, sk_revision)
+// tag tuple that identifies a logical task across its retries.
+type siblingAttemptKey struct {
+	builderName string
+	skName      string
+	skRevision  string
+}
+
+// GroupSiblingAttempts groups the given TestingOutputs by logical task, as
+// identified by their builder_name, sk_name, and sk_revision Swarming tags, and
+// sorts each group by sk_attempt so that earlier attempts come first.
+func GroupSiblingAttempts(tos []*TestingOutputs) map[siblingAttemptKey][]*TestingOutputs {
+	groups := map[siblingAttemptKey][]*TestingOutputs{}
+	for _, to := range tos {
+		tags := tagMap(to.SwarmingSummary.Request.Tags)
+		key := siblingAttemptKey{
+			builderName: tags[builderNameTag],
+			skName:      tags[skNameTag],
+			skRevision:  tags[skRevisionTag],
+		}
+		groups[key] = append(groups[key], to)
+	}
+	for _, group := range groups {
+		sort.Slice(group, func(i, j int) bool {
+			return attemptNumber(group[i]) < attemptNumber(group[j])
+		})
+	}
+	return groups
+}
+
+// tagMap parses Swarming tags of the form "key:value" into a key-to-value map.
+func tagMap(tags []string) map[string]string {
+	m := make(map[string]string, len(tags))
+	for _, tag := range tags {
+		parts := strings.SplitN(tag, ":", 2)
+		if len(parts) == 2 {
+			m[parts[0]] = parts[1]
+		}
+	}
+	return m
+}
+
+// attemptNumber returns the sk_attempt tag value for to, or 0 if it is absent
+// or unparseable.
+func attemptNumber(to *TestingOutputs) int {
+	tags := tagMap(to.SwarmingSummary.Request.Tags)
+	n, err := strconv.Atoi(tags[skAttemptTag])
+	if err != nil {
+		return 0
+	}
+	return n
+}
+
+// RunMultiTaskChecks groups tos into sibling attempts of the same logical task
+// and runs checks against each group, synthesizing a Result for every group a
+// check fires on. This is the multi-task analog of RunChecks, which only ever
+// considers one TestingOutputs at a time.
+func RunMultiTaskChecks(tos []*TestingOutputs, checks []MultiTaskFailureModeCheck) []Result {
+	var results []Result
+	for _, group := range GroupSiblingAttempts(tos) {
+		for _, check := range checks {
+			if check.Check(group) {
+				results = append(results, Result{Name: check.Name(), DebugText: check.DebugText()})
+			}
+		}
+	}
+	return results
+}
@@ -0,0 +1,156 @@
+package tefmocheck
+
+import "testing"
+
+func attemptOutputs(state string, failure, internalFailure bool) *TestingOutputs {
+	return &TestingOutputs{
+		SwarmingSummary: &SwarmingTaskSummary{
+			Results: &SwarmingRpcsTaskResult{
+				State:           state,
+				Failure:         failure,
+				InternalFailure: internalFailure,
+			},
+		},
+	}
+}
+
+func TestTaskFlakeCheck_Check(t *testing.T) {
+	cases := []struct {
+		name     string
+		attempts []*TestingOutputs
+		want     bool
+	}{
+		{
+			name: "completed failure then success fires",
+			attempts: []*TestingOutputs{
+				attemptOutputs("COMPLETED", true, false),
+				attemptOutputs("COMPLETED", false, false),
+			},
+			want: true,
+		},
+		{
+			name: "non-completed terminal state then success fires",
+			attempts: []*TestingOutputs{
+				attemptOutputs("TIMED_OUT", false, false),
+				attemptOutputs("COMPLETED", false, false),
+			},
+			want: true,
+		},
+		{
+			name: "bot died then success fires",
+			attempts: []*TestingOutputs{
+				attemptOutputs("BOT_DIED", false, false),
+				attemptOutputs("COMPLETED", false, false),
+			},
+			want: true,
+		},
+		{
+			name: "all attempts succeed does not fire",
+			attempts: []*TestingOutputs{
+				attemptOutputs("COMPLETED", false, false),
+				attemptOutputs("COMPLETED", false, false),
+			},
+			want: false,
+		},
+		{
+			name: "single failing attempt does not fire",
+			attempts: []*TestingOutputs{
+				attemptOutputs("COMPLETED", true, false),
+			},
+			want: false,
+		},
+		{
+			name: "failure after success does not fire",
+			attempts: []*TestingOutputs{
+				attemptOutputs("COMPLETED", false, false),
+				attemptOutputs("COMPLETED", true, false),
+			},
+			want: false,
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			check := &taskFlakeCheck{}
+			if got := check.Check(c.attempts); got != c.want {
+				t.Errorf("Check() = %v, want %v", got, c.want)
+			}
+		})
+	}
+}
+
+func TestGroupSiblingAttempts(t *testing.T) {
+	attempt := func(builder, name, revision, attemptNum string) *TestingOutputs {
+		return &TestingOutputs{
+			SwarmingSummary: &SwarmingTaskSummary{
+				Request: &SwarmingRpcsTaskRequest{
+					Tags: []string{
+						"builder_name:" + builder,
+						"sk_name:" + name,
+						"sk_revision:" + revision,
+						"sk_attempt:" + attemptNum,
+					},
+				},
+				Results: &SwarmingRpcsTaskResult{State: "COMPLETED"},
+			},
+		}
+	}
+
+	a1 := attempt("Test-Linux", "my_test", "abc123", "1")
+	a0 := attempt("Test-Linux", "my_test", "abc123", "0")
+	other := attempt("Test-Mac", "my_test", "abc123", "0")
+
+	groups := GroupSiblingAttempts([]*TestingOutputs{a1, a0, other})
+	if len(groups) != 2 {
+		t.Fatalf("got %d groups, want 2", len(groups))
+	}
+
+	key := siblingAttemptKey{builderName: "Test-Linux", skName: "my_test", skRevision: "abc123"}
+	group, ok := groups[key]
+	if !ok {
+		t.Fatalf("no group for key %+v", key)
+	}
+	if len(group) != 2 {
+		t.Fatalf("got %d attempts in group, want 2", len(group))
+	}
+	if group[0] != a0 || group[1] != a1 {
+		t.Errorf("group not sorted earliest-attempt-first: %+v", group)
+	}
+}
+
+func TestRunMultiTaskChecks(t *testing.T) {
+	attempt := func(attemptNum, state string) *TestingOutputs {
+		return &TestingOutputs{
+			SwarmingSummary: &SwarmingTaskSummary{
+				Request: &SwarmingRpcsTaskRequest{
+					Tags: []string{
+						"builder_name:Test-Linux",
+						"sk_name:my_test",
+						"sk_revision:abc123",
+						"sk_attempt:" + attemptNum,
+					},
+				},
+				Results: &SwarmingRpcsTaskResult{State: state},
+			},
+		}
+	}
+
+	flaky := []*TestingOutputs{
+		attempt("0", "TIMED_OUT"),
+		attempt("1", "COMPLETED"),
+	}
+	results := RunMultiTaskChecks(flaky, MultiTaskChecks)
+	if len(results) != 1 {
+		t.Fatalf("got %d results, want 1: %+v", len(results), results)
+	}
+	if results[0].Name != "flake_on_retry" {
+		t.Errorf("got name %q, want %q", results[0].Name, "flake_on_retry")
+	}
+
+	clean := []*TestingOutputs{
+		attempt("0", "COMPLETED"),
+		attempt("1", "COMPLETED"),
+	}
+	if results := RunMultiTaskChecks(clean, MultiTaskChecks); len(results) != 0 {
+		t.Errorf("got %d results for a clean run, want 0: %+v", len(results), results)
+	}
+}
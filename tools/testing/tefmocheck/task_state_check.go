@@ -24,6 +24,16 @@ func (c *taskStateCheck) DebugText() string {
 	return debugTextForSwarmingSummary(c.swarmingSummary)
 }
 
+func (c *taskStateCheck) Result() CheckResult {
+	r := newCheckResult(c.Name())
+	r.Category = "task_state"
+	r.State = c.swarmingSummary.Results.State
+	r.SwarmingTaskId = c.swarmingSummary.Results.TaskId
+	r.SwarmingBotId = c.swarmingSummary.Results.BotId
+	r.Severity = SeverityWarning
+	return r
+}
+
 // taskFailureCheck checks if the swarming task failed.
 type taskFailureCheck struct {
 	swarmingSummary *SwarmingTaskSummary
@@ -42,6 +52,17 @@ func (c *taskFailureCheck) DebugText() string {
 	return debugTextForSwarmingSummary(c.swarmingSummary)
 }
 
+func (c *taskFailureCheck) Result() CheckResult {
+	r := newCheckResult(c.Name())
+	r.Category = "task_failure"
+	r.State = c.swarmingSummary.Results.State
+	r.Failure = c.swarmingSummary.Results.Failure
+	r.SwarmingTaskId = c.swarmingSummary.Results.TaskId
+	r.SwarmingBotId = c.swarmingSummary.Results.BotId
+	r.Severity = SeverityError
+	return r
+}
+
 // taskInternalFailureCheck checks if the swarming task internally failed.
 type taskInternalFailureCheck struct {
 	swarmingSummary *SwarmingTaskSummary
@@ -60,6 +81,17 @@ func (c *taskInternalFailureCheck) DebugText() string {
 	return debugTextForSwarmingSummary(c.swarmingSummary)
 }
 
+func (c *taskInternalFailureCheck) Result() CheckResult {
+	r := newCheckResult(c.Name())
+	r.Category = "task_internal_failure"
+	r.State = c.swarmingSummary.Results.State
+	r.InternalFailure = c.swarmingSummary.Results.InternalFailure
+	r.SwarmingTaskId = c.swarmingSummary.Results.TaskId
+	r.SwarmingBotId = c.swarmingSummary.Results.BotId
+	r.Severity = SeverityError
+	return r
+}
+
 func debugTextForSwarmingSummary(swarmingSummary *SwarmingTaskSummary) string {
 	ret := fmt.Sprintf("Swarming task state: %s.", swarmingSummary.Results.State)
 	if swarmingSummary.Results.Failure {
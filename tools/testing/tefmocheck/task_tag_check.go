@@ -0,0 +1,84 @@
+package tefmocheck
+
+import (
+	"fmt"
+	"path"
+)
+
+// taskDimensionCheck checks if the swarming task ran on (or was scheduled for) a bot
+// with a given dimension key/value pair, as requested in any of the task's task slices.
+type taskDimensionCheck struct {
+	Key             string
+	Value           string
+	swarmingSummary *SwarmingTaskSummary
+}
+
+func (c *taskDimensionCheck) Check(to *TestingOutputs) bool {
+	c.swarmingSummary = to.SwarmingSummary
+	for _, slice := range c.swarmingSummary.Request.TaskSlices {
+		for _, dim := range slice.Properties.Dimensions {
+			if dim.Key == c.Key && dim.Value == c.Value {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (c *taskDimensionCheck) Name() string {
+	return path.Join("bot_dimension", fmt.Sprintf("%s:%s", c.Key, c.Value))
+}
+
+func (c *taskDimensionCheck) DebugText() string {
+	return fmt.Sprintf("%s\nTask was scheduled with dimension %s:%s.",
+		debugTextForSwarmingSummary(c.swarmingSummary), c.Key, c.Value)
+}
+
+// taskTagCheck checks if the swarming task carried a given Swarming tag, e.g.
+// "pool:Skia" or "os:Linux".
+type taskTagCheck struct {
+	Key             string
+	Value           string
+	swarmingSummary *SwarmingTaskSummary
+}
+
+func (c *taskTagCheck) Check(to *TestingOutputs) bool {
+	c.swarmingSummary = to.SwarmingSummary
+	wantTag := fmt.Sprintf("%s:%s", c.Key, c.Value)
+	for _, tag := range c.swarmingSummary.Request.Tags {
+		if tag == wantTag {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *taskTagCheck) Name() string {
+	return path.Join("bot_tag", fmt.Sprintf("%s:%s", c.Key, c.Value))
+}
+
+func (c *taskTagCheck) DebugText() string {
+	return fmt.Sprintf("%s\nTask was tagged %s:%s.",
+		debugTextForSwarmingSummary(c.swarmingSummary), c.Key, c.Value)
+}
+
+// NewTaskDimensionCheck returns a FailureModeCheck that fires when the task was
+// scheduled for a bot with the given dimension key/value pair.
+func NewTaskDimensionCheck(key, value string) FailureModeCheck {
+	return &taskDimensionCheck{Key: key, Value: value}
+}
+
+// NewTaskTagCheck returns a FailureModeCheck that fires when the task carried the
+// given Swarming tag.
+func NewTaskTagCheck(key, value string) FailureModeCheck {
+	return &taskTagCheck{Key: key, Value: value}
+}
+
+// TaskMetadataChecks contains checks that key off of how a task was scheduled
+// (its requested bot dimensions and Swarming tags) rather than how it ran, so that
+// infra can pivot from a synthetic test directly to the affected fleet subset.
+var TaskMetadataChecks []FailureModeCheck = []FailureModeCheck{
+	NewTaskDimensionCheck("device_type", "nuc7"),
+	NewTaskDimensionCheck("gpu", "none"),
+	NewTaskTagCheck("pool", "Skia"),
+}
@@ -0,0 +1,64 @@
+package tefmocheck
+
+import "testing"
+
+func taskSliceOutputs(dimensions []*SwarmingRpcsStringPair, tags []string) *TestingOutputs {
+	return &TestingOutputs{
+		SwarmingSummary: &SwarmingTaskSummary{
+			Request: &SwarmingRpcsTaskRequest{
+				Tags: tags,
+				TaskSlices: []*SwarmingRpcsTaskSlice{
+					{Properties: &SwarmingRpcsTaskProperties{Dimensions: dimensions}},
+				},
+			},
+			Results: &SwarmingRpcsTaskResult{},
+		},
+	}
+}
+
+func TestTaskDimensionCheck_Check(t *testing.T) {
+	to := taskSliceOutputs([]*SwarmingRpcsStringPair{
+		{Key: "device_type", Value: "nuc7"},
+		{Key: "pool", Value: "Skia"},
+	}, nil)
+
+	cases := []struct {
+		name, key, value string
+		want             bool
+	}{
+		{"matches", "device_type", "nuc7", true},
+		{"wrong value", "device_type", "sailfish", false},
+		{"wrong key", "os", "nuc7", false},
+		{"other dimension matches", "pool", "Skia", true},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			check := &taskDimensionCheck{Key: c.key, Value: c.value}
+			if got := check.Check(to); got != c.want {
+				t.Errorf("Check() = %v, want %v", got, c.want)
+			}
+		})
+	}
+}
+
+func TestTaskTagCheck_Check(t *testing.T) {
+	to := taskSliceOutputs(nil, []string{"pool:Skia", "os:Linux"})
+
+	cases := []struct {
+		name, key, value string
+		want             bool
+	}{
+		{"matches", "pool", "Skia", true},
+		{"wrong value", "pool", "CT", false},
+		{"wrong key", "cpu", "Skia", false},
+		{"other tag matches", "os", "Linux", true},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			check := &taskTagCheck{Key: c.key, Value: c.value}
+			if got := check.Check(to); got != c.want {
+				t.Errorf("Check() = %v, want %v", got, c.want)
+			}
+		})
+	}
+}